@@ -7,11 +7,11 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/sirupsen/logrus"
 
 	"github.com/stellar/go/ingest/ledgerbackend"
 	"github.com/stellar/go/network"
-	support "github.com/stellar/go/support/config"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/ledgerbucketwindow"
 )
@@ -34,6 +34,122 @@ func (f LogFormat) String() string {
 	}
 }
 
+// ParseLogFormat parses the TOML/CLI string form of a LogFormat.
+func ParseLogFormat(s string) (LogFormat, error) {
+	switch s {
+	case "text", "":
+		return LogFormatText, nil
+	case "json":
+		return LogFormatJSON, nil
+	default:
+		return 0, fmt.Errorf("unknown log format %q, want one of: text, json", s)
+	}
+}
+
+// UnmarshalText lets BurntSushi/toml (and anything else using the
+// encoding.TextUnmarshaler convention) decode the quoted string gen-config
+// writes for this field back into its underlying int.
+func (f *LogFormat) UnmarshalText(text []byte) error {
+	parsed, err := ParseLogFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// MarshalText is the encoding.TextMarshaler counterpart to UnmarshalText.
+func (f LogFormat) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// LedgerBackendType selects which ledgerbackend.LedgerBackend implementation
+// Daemon constructs. Captive is the default and spawns (or reuses) a local
+// stellar-core subprocess; RemoteCaptive talks to an out-of-process
+// captive-core over HTTP so several RPC instances can share one core; RPC
+// is reserved for a future backend that reads ledgers from another
+// soroban-rpc instance instead of core.
+type LedgerBackendType int
+
+const (
+	LedgerBackendCaptiveCore LedgerBackendType = iota
+	LedgerBackendRemoteCaptiveCore
+	LedgerBackendRPC
+)
+
+func (t LedgerBackendType) String() string {
+	switch t {
+	case LedgerBackendCaptiveCore:
+		return "captive"
+	case LedgerBackendRemoteCaptiveCore:
+		return "remote-captive"
+	case LedgerBackendRPC:
+		return "rpc"
+	default:
+		panic(fmt.Sprintf("unknown ledger backend type: %d", t))
+	}
+}
+
+// ParseLedgerBackendType parses the TOML/CLI string form of a
+// LedgerBackendType.
+func ParseLedgerBackendType(s string) (LedgerBackendType, error) {
+	switch s {
+	case "captive", "":
+		return LedgerBackendCaptiveCore, nil
+	case "remote-captive":
+		return LedgerBackendRemoteCaptiveCore, nil
+	case "rpc":
+		return LedgerBackendRPC, nil
+	default:
+		return 0, fmt.Errorf("unknown ledger backend type %q, want one of: captive, remote-captive, rpc", s)
+	}
+}
+
+// UnmarshalText lets BurntSushi/toml (and anything else using the
+// encoding.TextUnmarshaler convention) decode the quoted string gen-config
+// writes for this field back into its underlying int.
+func (t *LedgerBackendType) UnmarshalText(text []byte) error {
+	parsed, err := ParseLedgerBackendType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalText is the encoding.TextMarshaler counterpart to UnmarshalText.
+func (t LedgerBackendType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// Duration is a time.Duration that round-trips through TOML the same way
+// LogFormat and LedgerBackendType do: gen-config writes it as its String()
+// form (e.g. "30s"), and UnmarshalText lets Read decode that string back
+// into a duration, which a bare time.Duration field can't do on its own.
+type Duration time.Duration
+
+// String renders d the same way time.Duration does (e.g. "2m0s").
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// UnmarshalText lets BurntSushi/toml (and anything else using the
+// encoding.TextUnmarshaler convention) decode the quoted duration string
+// gen-config writes for this field back into its underlying int64.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalText is the encoding.TextMarshaler counterpart to UnmarshalText.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
 type CaptiveCoreConfig = ledgerbackend.CaptiveCoreToml
 
 // Config represents the configuration of a friendbot server
@@ -57,41 +173,47 @@ type Config struct {
 	Endpoint                         string        `toml:"ENDPOINT" valid:"optional"`
 	AdminEndpoint                    string        `toml:"ADMIN_ENDPOINT" valid:"optional"`
 	CheckpointFrequency              uint32        `toml:"CHECKPOINT_FREQUENCY" valid:"optional"`
-	CoreRequestTimeout               time.Duration `toml:"CORE_REQUEST_TIMEOUT" valid:"optional"`
+	CoreRequestTimeout               Duration      `toml:"CORE_REQUEST_TIMEOUT" valid:"optional"`
 	DefaultEventsLimit               uint          `toml:"DEFAULT_EVENTS_LIMIT" valid:"optional"`
 	EventLedgerRetentionWindow       uint32        `toml:"EVENT_LEDGER_RETENTION_WINDOW" valid:"optional"`
 	FriendbotURL                     string        `toml:"FRIENDBOT_URL" valid:"optional"`
 	HistoryArchiveURLs               []string      `toml:"HISTORY_ARCHIVE_URLS" valid:"required"`
-	IngestionTimeout                 time.Duration `toml:"INGESTION_TIMEOUT" valid:"optional"`
+	IngestionTimeout                 Duration      `toml:"INGESTION_TIMEOUT" valid:"optional"`
+	LedgerBackendType                LedgerBackendType `toml:"LEDGER_BACKEND_TYPE" valid:"optional"`
+	RemoteCaptiveCoreURL             string        `toml:"REMOTE_CAPTIVE_CORE_URL" valid:"optional"`
 	LogFormat                        LogFormat     `toml:"LOG_FORMAT" valid:"optional"`
 	LogLevel                         logrus.Level  `toml:"LOG_LEVEL" valid:"optional"`
 	MaxEventsLimit                   uint          `toml:"MAX_EVENTS_LIMIT" valid:"optional"`
-	MaxHealthyLedgerLatency          time.Duration `toml:"MAX_HEALTHY_LEDGER_LATENCY" valid:"optional"`
+	MaxHealthyLedgerLatency          Duration      `toml:"MAX_HEALTHY_LEDGER_LATENCY" valid:"optional"`
 	NetworkPassphrase                string        `toml:"NETWORK_PASSPHRASE" valid:"required"`
 	PreflightWorkerCount             uint          `toml:"PREFLIGHT_WORKER_COUNT" valid:"optional"`
 	PreflightWorkerQueueSize         uint          `toml:"PREFLIGHT_WORKER_QUEUE_SIZE" valid:"optional"`
 	SQLiteDBPath                     string        `toml:"SQLITE_DB_PATH" valid:"optional"`
 	TransactionLedgerRetentionWindow uint32        `toml:"TRANSACTION_LEDGER_RETENTION_WINDOW" valid:"optional"`
+	StartWhenSynchronized            bool          `toml:"START_WHEN_SYNCHRONIZED" valid:"optional"`
+	AdminBearerToken                 string        `toml:"ADMIN_BEARER_TOKEN" valid:"optional"`
 }
 
 func (cfg *Config) SetDefaults() {
 	cfg.CaptiveCoreConfig.HTTPPort = 11626
 	cfg.CaptiveCoreConfig.NetworkPassphrase = cfg.NetworkPassphrase
 	cfg.CheckpointFrequency = 64
-	cfg.CoreRequestTimeout = 2 * time.Second
+	cfg.CoreRequestTimeout = Duration(2 * time.Second)
 	cfg.DefaultEventsLimit = 100
 	cfg.Endpoint = "localhost:8000"
 	cfg.EventLedgerRetentionWindow = uint32(ledgerbucketwindow.DefaultEventLedgerRetentionWindow)
-	cfg.IngestionTimeout = 30 * time.Minute
+	cfg.IngestionTimeout = Duration(30 * time.Minute)
+	cfg.LedgerBackendType = LedgerBackendCaptiveCore
 	cfg.LogFormat = LogFormatText
 	cfg.LogLevel = logrus.InfoLevel
 	cfg.MaxEventsLimit = 10000
-	cfg.MaxHealthyLedgerLatency = 30 * time.Second
+	cfg.MaxHealthyLedgerLatency = Duration(30 * time.Second)
 	cfg.NetworkPassphrase = network.FutureNetworkPassphrase
 	cfg.PreflightWorkerCount = uint(runtime.NumCPU())
 	cfg.PreflightWorkerQueueSize = uint(runtime.NumCPU())
 	cfg.SQLiteDBPath = "soroban_rpc.sqlite"
 	cfg.TransactionLedgerRetentionWindow = 1440
+	cfg.StartWhenSynchronized = true
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -100,69 +222,192 @@ func (cfg *Config) SetDefaults() {
 	cfg.CaptiveCoreStoragePath = cwd
 }
 
+// Read parses the TOML file at path into a Config. If the file sets
+// STRICT=true, any key in the file that doesn't correspond to a field on
+// Config is treated as an error rather than silently ignored, to catch
+// typos like EVENT_RETENTION_WINDOW (missing "LEDGER") before they cause a
+// confusing runtime default.
 func Read(path string) (*Config, error) {
 	cfg := &Config{}
-	// TODO: Enforce strict parsing here
-	err := support.Read(path, cfg)
+	metadata, err := toml.DecodeFile(path, cfg)
 	if err != nil {
-		switch cause := errors.Cause(err).(type) {
-		case *support.InvalidConfigError:
-			return nil, errors.Wrap(cause, "config file")
-		default:
-			return nil, err
+		return nil, errors.Wrap(err, "config file")
+	}
+	if cfg.Strict {
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("unknown config keys in %s: %v", path, undecoded)
 		}
 	}
 	return cfg, nil
 }
 
-func (cfg *Config) Validate() error {
-	if cfg.DefaultEventsLimit > cfg.MaxEventsLimit {
-		return fmt.Errorf(
-			"default-events-limit (%v) cannot exceed max-events-limit (%v)\n",
-			cfg.DefaultEventsLimit,
-			cfg.MaxEventsLimit,
-		)
-	}
-
-	if len(cfg.HistoryArchiveURLs) == 0 {
-		return cannotBeBlank(
-			"history-archive-urls",
-			"HISTORY_ARCHIVE_URLS",
-		)
+// Options returns the declarative descriptor for each configurable field of
+// cfg. Flags(), env parsing, the gen-config TOML template, and Validate all
+// derive from it; Read decodes TOML by the fields' struct tags instead. A
+// field without a descriptor here gets none of these.
+func (cfg *Config) Options() ConfigOptions {
+	return ConfigOptions{
+		{
+			Name:      "history-archive-urls",
+			Usage:     "Comma separated list of history archive URLs",
+			ConfigKey: &cfg.HistoryArchiveURLs,
+			Required:  true,
+		},
+		{
+			Name:      "network-passphrase",
+			Usage:     "Network passphrase of the Stellar network transactions should be signed for",
+			ConfigKey: &cfg.NetworkPassphrase,
+			Required:  true,
+		},
+		{
+			Name:      "stellar-core-binary-path",
+			Usage:     "Path to the stellar-core binary, required when ledger-backend-type=captive",
+			ConfigKey: &cfg.StellarCoreBinaryPath,
+			Validate: func(o *ConfigOption) error {
+				if cfg.LedgerBackendType == LedgerBackendCaptiveCore && cfg.StellarCoreBinaryPath == "" {
+					return cannotBeBlank(o.Name, o.EnvVar())
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "remote-captive-core-url",
+			Usage:     "URL of an out-of-process captive-core to use, required when ledger-backend-type=remote-captive",
+			ConfigKey: &cfg.RemoteCaptiveCoreURL,
+			Validate: func(o *ConfigOption) error {
+				if cfg.LedgerBackendType == LedgerBackendRemoteCaptiveCore && cfg.RemoteCaptiveCoreURL == "" {
+					return cannotBeBlank(o.Name, o.EnvVar())
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "captive-core-storage-path",
+			TomlKey:   "CAPTIVE_CORE_STORAGE_PATH",
+			Usage:     "Directory to store captive core's bucket and SQL databases in",
+			ConfigKey: &cfg.CaptiveCoreStoragePath,
+		},
+		{
+			Name:      "endpoint",
+			Usage:     "Address and port to listen for JSON-RPC requests on",
+			ConfigKey: &cfg.Endpoint,
+		},
+		{
+			Name:      "admin-endpoint",
+			Usage:     "Address and port to listen for admin HTTP requests on. Disabled if blank",
+			ConfigKey: &cfg.AdminEndpoint,
+		},
+		{
+			Name:      "friendbot-url",
+			Usage:     "The friendbot URL to be returned by getNetwork RPC calls",
+			ConfigKey: &cfg.FriendbotURL,
+		},
+		{
+			Name:      "sqlite-db-path",
+			Usage:     "SQLite DB path used to store ledger entries, events, and transactions",
+			ConfigKey: &cfg.SQLiteDBPath,
+		},
+		{
+			Name:      "default-events-limit",
+			Usage:     "Default cap on the amount of events allowed in a single getEvents response",
+			ConfigKey: &cfg.DefaultEventsLimit,
+		},
+		{
+			Name:      "max-events-limit",
+			Usage:     "Maximum amount of events allowed in a single getEvents response",
+			ConfigKey: &cfg.MaxEventsLimit,
+			Validate: func(o *ConfigOption) error {
+				if cfg.DefaultEventsLimit > cfg.MaxEventsLimit {
+					return fmt.Errorf(
+						"default-events-limit (%v) cannot exceed max-events-limit (%v)",
+						cfg.DefaultEventsLimit,
+						cfg.MaxEventsLimit,
+					)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "event-ledger-retention-window",
+			Usage:     "Number of recent ledgers to retain events for",
+			ConfigKey: &cfg.EventLedgerRetentionWindow,
+		},
+		{
+			Name:      "transaction-ledger-retention-window",
+			Usage:     "Number of recent ledgers to retain transactions for",
+			ConfigKey: &cfg.TransactionLedgerRetentionWindow,
+		},
+		{
+			Name:      "checkpoint-frequency",
+			Usage:     "Number of ledgers between checkpoints",
+			ConfigKey: &cfg.CheckpointFrequency,
+		},
+		{
+			Name:      "preflight-worker-count",
+			Usage:     "Number of workers used to compute preflights",
+			ConfigKey: &cfg.PreflightWorkerCount,
+		},
+		{
+			Name:      "preflight-worker-queue-size",
+			Usage:     "Maximum number of outstanding preflight requests to queue",
+			ConfigKey: &cfg.PreflightWorkerQueueSize,
+		},
+		{
+			Name:      "ledger-backend-type",
+			Usage:     "Which ledger backend to use: captive, remote-captive, or rpc",
+			ConfigKey: &cfg.LedgerBackendType,
+		},
+		{
+			Name:      "log-format",
+			Usage:     "Format used for logging: text or json",
+			ConfigKey: &cfg.LogFormat,
+		},
+		{
+			Name:      "log-level",
+			Usage:     "Logging level: panic, fatal, error, warn, info, or debug",
+			ConfigKey: &cfg.LogLevel,
+		},
+		{
+			Name:      "core-request-timeout",
+			Usage:     "Timeout applied to requests soroban-rpc makes against stellar-core",
+			ConfigKey: &cfg.CoreRequestTimeout,
+		},
+		{
+			Name:      "ingestion-timeout",
+			Usage:     "Timeout applied to a single ledger's ingestion",
+			ConfigKey: &cfg.IngestionTimeout,
+		},
+		{
+			Name:      "max-healthy-ledger-latency",
+			Usage:     "Maximum duration ingestion may lag behind the latest ledger close time before /readyz reports not ready",
+			ConfigKey: &cfg.MaxHealthyLedgerLatency,
+		},
+		{
+			Name:      "start-when-synchronized",
+			Usage:     "Delay binding the public JSON-RPC listener until ingestion has caught up to the history archive tip",
+			ConfigKey: &cfg.StartWhenSynchronized,
+		},
+		{
+			Name:      "admin-bearer-token",
+			Usage:     "Bearer token required on /admin/* requests. Admin API is unauthenticated if blank",
+			ConfigKey: &cfg.AdminBearerToken,
+		},
 	}
+}
 
-	if cfg.NetworkPassphrase == "" {
-		return cannotBeBlank(
-			"network-passphrase",
-			"NETWORK_PASSPHRASE",
-		)
+func (cfg *Config) Validate() error {
+	if err := cfg.Options().Validate(); err != nil {
+		return err
 	}
 
-	// if cfg.CaptiveCoreConfigPath == "" {
-	// 	return cannotBeBlank(
-	// 		"captive-core-config-path",
-	// 		"CAPTIVE_CORE_CONFIG_PATH",
-	// 	)
-	// }
 	if cfg.Strict && cfg.CaptiveCoreConfig.BucketDirPath != "" {
 		return errors.New("could not unmarshal captive core toml: setting BUCKET_DIR_PATH is disallowed for Captive Core, use CAPTIVE_CORE_STORAGE_PATH instead")
 	}
 	// Validate home domains etc as in CaptiveCoreToml.validate
 
-	if cfg.StellarCoreBinaryPath == "" {
-		return cannotBeBlank(
-			"stellar-core-binary-path",
-			"STELLAR_CORE_BINARY_PATH",
-		)
-	}
-
 	return nil
 }
 
-func cannotBeBlank(name, envVar string) error {
-	return fmt.Errorf("Invalid config: %s is blank. Please specify --%s on the command line or set the %s environment variable.", name, name, envVar)
-}
-
 // Merge a and b, preferring values from b. Neither config is modified, instead
 // a new struct is returned.
 // TODO: Unit-test this
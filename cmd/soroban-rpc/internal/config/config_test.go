@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestMergePrefersNonZeroOverrides(t *testing.T) {
+	base := Config{
+		Endpoint:          "localhost:8000",
+		NetworkPassphrase: "base passphrase",
+		Strict:            false,
+		CheckpointFrequency: 64,
+		HistoryArchiveURLs:  []string{"https://base.example.com"},
+	}
+	base.CaptiveCoreConfig.HTTPPort = 11626
+
+	override := Config{
+		Endpoint: "localhost:9000",
+		Strict:   true,
+	}
+	override.CaptiveCoreConfig.HTTPPort = 11727
+
+	merged := base.Merge(override)
+
+	if merged.Endpoint != "localhost:9000" {
+		t.Errorf("expected overridden string field to win, got %q", merged.Endpoint)
+	}
+	if merged.NetworkPassphrase != "base passphrase" {
+		t.Errorf("expected zero-value string field to fall back to base, got %q", merged.NetworkPassphrase)
+	}
+	if !merged.Strict {
+		t.Errorf("expected overridden bool field to win")
+	}
+	if merged.CheckpointFrequency != 64 {
+		t.Errorf("expected zero-value uint32 field to fall back to base, got %d", merged.CheckpointFrequency)
+	}
+	if len(merged.HistoryArchiveURLs) != 1 || merged.HistoryArchiveURLs[0] != "https://base.example.com" {
+		t.Errorf("expected zero-value slice field to fall back to base, got %v", merged.HistoryArchiveURLs)
+	}
+	if merged.CaptiveCoreConfig.HTTPPort != 11727 {
+		t.Errorf("expected embedded struct field to merge recursively, got %d", merged.CaptiveCoreConfig.HTTPPort)
+	}
+}
+
+func TestOptionsValidateCatchesBlankRequiredField(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+	cfg.HistoryArchiveURLs = nil
+
+	err := cfg.Options().Validate()
+	if err == nil {
+		t.Fatal("expected an error for a blank required field")
+	}
+}
+
+func TestOptionsValidatePassesWithDefaults(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+	cfg.HistoryArchiveURLs = []string{"https://history.example.com"}
+	cfg.StellarCoreBinaryPath = "/usr/bin/stellar-core"
+
+	if err := cfg.Options().Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestCLIOverridesApplyToUnbindableTypes exercises the flags toFlag can't
+// wire a cli.Flag.Destination for directly (uint32 fields, and custom types
+// like LogFormat/LedgerBackendType) end to end through an actual cli.App, to
+// make sure SetValues picks up CLI overrides for them rather than silently
+// keeping the file/default value.
+func TestCLIOverridesApplyToUnbindableTypes(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+
+	flags, err := cfg.Options().Flags()
+	if err != nil {
+		t.Fatalf("Flags() returned error: %v", err)
+	}
+
+	app := &cli.App{
+		Flags: flags,
+		Action: func(c *cli.Context) error {
+			return cfg.Options().SetValues(c)
+		},
+	}
+
+	args := []string{
+		"soroban-rpc",
+		"--checkpoint-frequency", "128",
+		"--ledger-backend-type", "remote-captive",
+		"--log-format", "json",
+	}
+	if err := app.Run(args); err != nil {
+		t.Fatalf("app.Run returned error: %v", err)
+	}
+
+	if cfg.CheckpointFrequency != 128 {
+		t.Errorf("expected --checkpoint-frequency to override the uint32 field, got %d", cfg.CheckpointFrequency)
+	}
+	if cfg.LedgerBackendType != LedgerBackendRemoteCaptiveCore {
+		t.Errorf("expected --ledger-backend-type to override LedgerBackendType, got %v", cfg.LedgerBackendType)
+	}
+	if cfg.LogFormat != LogFormatJSON {
+		t.Errorf("expected --log-format to override LogFormat, got %v", cfg.LogFormat)
+	}
+}
+
+// TestUnsetCLIFlagsKeepFileOrDefaultValue makes sure options toFlag can't
+// bind a Destination for still layer file/defaults correctly: with no CLI
+// args at all, SetValues should leave whatever SetDefaults (or a config
+// file merge) already put in cfg untouched.
+func TestUnsetCLIFlagsKeepFileOrDefaultValue(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+	cfg.LedgerBackendType = LedgerBackendRemoteCaptiveCore
+
+	flags, err := cfg.Options().Flags()
+	if err != nil {
+		t.Fatalf("Flags() returned error: %v", err)
+	}
+
+	app := &cli.App{
+		Flags: flags,
+		Action: func(c *cli.Context) error {
+			return cfg.Options().SetValues(c)
+		},
+	}
+
+	if err := app.Run([]string{"soroban-rpc"}); err != nil {
+		t.Fatalf("app.Run returned error: %v", err)
+	}
+
+	if cfg.LedgerBackendType != LedgerBackendRemoteCaptiveCore {
+		t.Errorf("expected LedgerBackendType to keep its pre-CLI value, got %v", cfg.LedgerBackendType)
+	}
+	if cfg.CheckpointFrequency != 64 {
+		t.Errorf("expected CheckpointFrequency to keep its default value, got %d", cfg.CheckpointFrequency)
+	}
+}
+
+// TestGenConfigRoundTripsThroughRead makes sure a gen-config template can be
+// fed straight back into --config-path: LogFormat, LedgerBackendType, and
+// Duration fields are all written as quoted strings, so Read must be able
+// to decode them back into their underlying int/int64-kinded fields.
+func TestGenConfigRoundTripsThroughRead(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+	cfg.LogFormat = LogFormatJSON
+	cfg.LedgerBackendType = LedgerBackendRemoteCaptiveCore
+	cfg.HistoryArchiveURLs = []string{"https://history.example.com"}
+	cfg.NetworkPassphrase = "test passphrase"
+	cfg.IngestionTimeout = Duration(90 * time.Second)
+
+	var buf bytes.Buffer
+	if err := cfg.Options().WriteTemplate(&buf); err != nil {
+		t.Fatalf("WriteTemplate returned error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "soroban-rpc.toml")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("writing generated config: %v", err)
+	}
+
+	roundTripped, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read(gen-config output) returned error: %v", err)
+	}
+	if roundTripped.LogFormat != LogFormatJSON {
+		t.Errorf("expected LogFormat to round-trip as json, got %v", roundTripped.LogFormat)
+	}
+	if roundTripped.LedgerBackendType != LedgerBackendRemoteCaptiveCore {
+		t.Errorf("expected LedgerBackendType to round-trip as remote-captive, got %v", roundTripped.LedgerBackendType)
+	}
+	if roundTripped.IngestionTimeout != Duration(90*time.Second) {
+		t.Errorf("expected IngestionTimeout to round-trip as 90s, got %v", roundTripped.IngestionTimeout)
+	}
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// ConfigOption is the single declarative source of truth for one
+// configuration value: its CLI flag, environment variable, gen-config TOML
+// key, and validation all live here, so a Config field that has a
+// descriptor in Config.Options picks up all of them from it. Config fields
+// without a descriptor (e.g. Strict) get none of them.
+type ConfigOption struct {
+	// Name is the flag name and the basis for the env var (upper-snake-cased).
+	Name string
+	// TomlKey is the key WriteTemplate writes for this option. Defaults to
+	// the upper-snake-cased Name if empty. Read does not use it: it decodes
+	// by the Config field's toml struct tag, which this key has to match.
+	TomlKey string
+	// Usage is shown in --help and in the gen-config template comment.
+	Usage string
+	// ConfigKey points at the field in a *Config this option populates.
+	ConfigKey interface{}
+	// DefaultValue is not read by Flags, SetValues, WriteTemplate or
+	// Config.SetDefaults; defaults are assigned by Config.SetDefaults, and a
+	// flag's default is whatever value its Config field holds when Flags runs.
+	DefaultValue interface{}
+	// Validate, if set, is run once after all layers are merged.
+	Validate func(*ConfigOption) error
+	// Required marks an option whose blank zero-value should fail Validate.
+	Required bool
+}
+
+func (o *ConfigOption) EnvVar() string {
+	return toEnvVar(o.Name)
+}
+
+func (o *ConfigOption) tomlKey() string {
+	if o.TomlKey != "" {
+		return o.TomlKey
+	}
+	return o.EnvVar()
+}
+
+// toEnvVar converts a kebab-case flag name to the SCREAMING_SNAKE_CASE
+// environment variable / TOML key the rest of this package has always used,
+// e.g. "history-archive-urls" -> "HISTORY_ARCHIVE_URLS".
+func toEnvVar(name string) string {
+	out := make([]rune, 0, len(name))
+	for _, r := range name {
+		if r == '-' {
+			out = append(out, '_')
+			continue
+		}
+		if r >= 'a' && r <= 'z' {
+			r = r - 'a' + 'A'
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// ConfigOptions is the full set of descriptors for a Config. Order matters:
+// it is the order flags are registered in and options are written to the
+// gen-config template.
+type ConfigOptions []*ConfigOption
+
+// Validate runs every option's Validate func (if any) plus the Required
+// check, returning the first failure.
+func (options ConfigOptions) Validate() error {
+	for _, option := range options {
+		if option.Required && isZero(option.ConfigKey) {
+			return cannotBeBlank(option.Name, option.EnvVar())
+		}
+		if option.Validate == nil {
+			continue
+		}
+		if err := option.Validate(option); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isZero(configKey interface{}) bool {
+	switch v := configKey.(type) {
+	case *string:
+		return *v == ""
+	case *uint:
+		return *v == 0
+	case *uint32:
+		return *v == 0
+	case *[]string:
+		return len(*v) == 0
+	default:
+		return false
+	}
+}
+
+// Flags turns every descriptor into a urfave/cli.Flag, bound (via
+// Destination) directly to the Config field it describes where cli supports
+// the field's type; SetValues copies the rest back. Because
+// cli.Flag.EnvVars is populated, urfave/cli itself implements the
+// CLI > env precedence for us; the file/defaults layer is whatever value
+// was already written into the Config field (and therefore into the flag's
+// Value) before Flags is called.
+func (options ConfigOptions) Flags() ([]cli.Flag, error) {
+	flags := make([]cli.Flag, 0, len(options))
+	for _, option := range options {
+		flag, err := option.toFlag()
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
+
+func (o *ConfigOption) toFlag() (cli.Flag, error) {
+	envVars := []string{o.EnvVar()}
+	switch dest := o.ConfigKey.(type) {
+	case *string:
+		return &cli.StringFlag{Name: o.Name, Usage: o.Usage, EnvVars: envVars, Value: *dest, Destination: dest}, nil
+	case *[]string:
+		return &cli.StringSliceFlag{Name: o.Name, Usage: o.Usage, EnvVars: envVars}, nil
+	case *bool:
+		return &cli.BoolFlag{Name: o.Name, Usage: o.Usage, EnvVars: envVars, Value: *dest, Destination: dest}, nil
+	case *uint:
+		return &cli.UintFlag{Name: o.Name, Usage: o.Usage, EnvVars: envVars, Value: *dest, Destination: dest}, nil
+	case *uint32:
+		// cli has no Uint32Flag, so this can't bind a Destination directly;
+		// SetValues copies the parsed value back after Flags runs.
+		return &cli.Uint64Flag{Name: o.Name, Usage: o.Usage, EnvVars: envVars, Value: uint64(*dest)}, nil
+	default:
+		// Custom types (LogFormat, LedgerBackendType, Duration,
+		// logrus.Level, ...) round-trip through their String()/Parse
+		// functions as a plain string flag; SetValues below does the
+		// conversion back.
+		value, err := o.tomlValue()
+		if err != nil {
+			return nil, err
+		}
+		value = strings.Trim(value, `"`)
+		return &cli.StringFlag{Name: o.Name, Usage: o.Usage, EnvVars: envVars, Value: value}, nil
+	}
+}
+
+// SetValues copies the values urfave/cli parsed for the flags Flags()
+// couldn't bind a Destination to ([]string and uint32 fields, and custom
+// types like LogFormat/LedgerBackendType/Duration/logrus.Level) back into their
+// Config fields. It must be called once after cli has parsed the flag set,
+// e.g. from an App's Before hook, for CLI/env overrides of those options to
+// take effect at all.
+func (options ConfigOptions) SetValues(c *cli.Context) error {
+	for _, option := range options {
+		switch dest := option.ConfigKey.(type) {
+		case *[]string:
+			// The slice flag carries no default, so only an explicit CLI/env
+			// value replaces the file/default one.
+			if c.IsSet(option.Name) {
+				*dest = c.StringSlice(option.Name)
+			}
+		case *uint32:
+			*dest = uint32(c.Uint64(option.Name))
+		case *LogFormat:
+			format, err := ParseLogFormat(c.String(option.Name))
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", option.Name, err)
+			}
+			*dest = format
+		case *LedgerBackendType:
+			backend, err := ParseLedgerBackendType(c.String(option.Name))
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", option.Name, err)
+			}
+			*dest = backend
+		case *Duration:
+			d, err := time.ParseDuration(c.String(option.Name))
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", option.Name, err)
+			}
+			*dest = Duration(d)
+		case *logrus.Level:
+			level, err := logrus.ParseLevel(c.String(option.Name))
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", option.Name, err)
+			}
+			*dest = level
+		}
+	}
+	return nil
+}
+
+// WriteTemplate writes a fully-commented TOML template of the current
+// effective values of every option, in the shape `soroban-rpc gen-config`
+// emits, so operators can capture exactly what a running instance resolved
+// its config to.
+func (options ConfigOptions) WriteTemplate(w io.Writer) error {
+	for i, option := range options {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if option.Usage != "" {
+			if _, err := fmt.Fprintf(w, "# %s\n", option.Usage); err != nil {
+				return err
+			}
+		}
+		value, err := option.tomlValue()
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s = %s\n", option.tomlKey(), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *ConfigOption) tomlValue() (string, error) {
+	switch v := o.ConfigKey.(type) {
+	case *string:
+		return fmt.Sprintf("%q", *v), nil
+	case *bool:
+		return fmt.Sprintf("%t", *v), nil
+	case *uint:
+		return fmt.Sprintf("%d", *v), nil
+	case *uint32:
+		return fmt.Sprintf("%d", *v), nil
+	case *[]string:
+		quoted := make([]string, len(*v))
+		for i, s := range *v {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]", nil
+	case fmt.Stringer:
+		return fmt.Sprintf("%q", v.String()), nil
+	default:
+		return "", fmt.Errorf("option %s: unsupported config value type %T", o.Name, o.ConfigKey)
+	}
+}
+
+func cannotBeBlank(name, envVar string) error {
+	return fmt.Errorf("Invalid config: %s is blank. Please specify --%s on the command line or set the %s environment variable.", name, name, envVar)
+}
@@ -0,0 +1,154 @@
+package daemon
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	supportlog "github.com/stellar/go/support/log"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/events"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/ingest"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/transactions"
+)
+
+// adminActionCounter tracks how many times each admin endpoint has been
+// invoked, labeled by action and outcome, so operators can alert on
+// unexpected reingestion or retention changes in production.
+var adminActionCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "soroban_rpc",
+	Subsystem: "admin",
+	Name:      "actions_total",
+	Help:      "Total number of admin API requests, labeled by action and outcome",
+}, []string{"action", "outcome"})
+
+func registerAdminMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(adminActionCounter)
+}
+
+// adminAuth wraps the admin mux with an optional bearer token check. If
+// token is empty, the admin API is left unauthenticated (matching the
+// existing /metrics and /debug/pprof behavior).
+func adminAuth(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	want := "Bearer " + token
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if subtle.ConstantTimeCompare([]byte(header), []byte(want)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// reingestRequest is the body of POST /admin/reingest.
+type reingestRequest struct {
+	FromLedger uint32 `json:"fromLedger"`
+	ToLedger   uint32 `json:"toLedger"`
+}
+
+func newReingestHandler(logger *supportlog.Entry, ingestService *ingest.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req reingestRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			adminActionCounter.WithLabelValues("reingest", "bad_request").Inc()
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.ToLedger < req.FromLedger {
+			adminActionCounter.WithLabelValues("reingest", "bad_request").Inc()
+			http.Error(w, "toLedger must be >= fromLedger", http.StatusBadRequest)
+			return
+		}
+		if err := ingestService.Reingest(r.Context(), req.FromLedger, req.ToLedger); err != nil {
+			logger.WithError(err).Error("admin reingest failed")
+			adminActionCounter.WithLabelValues("reingest", "error").Inc()
+			http.Error(w, "reingestion failed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		adminActionCounter.WithLabelValues("reingest", "ok").Inc()
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// retentionRequest is the body of POST /admin/retention.
+type retentionRequest struct {
+	Events       uint32 `json:"events"`
+	Transactions uint32 `json:"transactions"`
+}
+
+func newRetentionHandler(logger *supportlog.Entry, eventStore *events.MemoryStore, transactionStore *transactions.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req retentionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			adminActionCounter.WithLabelValues("retention", "bad_request").Inc()
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Events != 0 {
+			eventStore.SetRetentionWindow(req.Events)
+		}
+		if req.Transactions != 0 {
+			if err := transactionStore.SetRetentionWindow(r.Context(), req.Transactions); err != nil {
+				logger.WithError(err).Error("admin retention change failed")
+				adminActionCounter.WithLabelValues("retention", "error").Inc()
+				http.Error(w, "retention change failed: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		adminActionCounter.WithLabelValues("retention", "ok").Inc()
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ingestionStatusResponse is the body of GET /admin/ingestion.
+type ingestionStatusResponse struct {
+	Cursor      uint32 `json:"cursor"`
+	LagLedgers  uint32 `json:"lagLedgers"`
+	RetryCount  uint64 `json:"retryCount"`
+}
+
+func newIngestionStatusHandler(ingestService *ingest.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := ingestService.Status()
+		adminActionCounter.WithLabelValues("ingestion_status", "ok").Inc()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ingestionStatusResponse{
+			Cursor:     status.Cursor,
+			LagLedgers: status.LagLedgers,
+			RetryCount: status.RetryCount,
+		})
+	}
+}
+
+// logLevelRequest is the body of POST /admin/log-level.
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+func newLogLevelHandler(logger *supportlog.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req logLevelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			adminActionCounter.WithLabelValues("log_level", "bad_request").Inc()
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		level, err := logrus.ParseLevel(strings.ToLower(req.Level))
+		if err != nil {
+			adminActionCounter.WithLabelValues("log_level", "bad_request").Inc()
+			http.Error(w, "invalid log level: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		logger.SetLevel(level)
+		logger.WithField("level", level.String()).Info("log level changed via admin API")
+		adminActionCounter.WithLabelValues("log_level", "ok").Inc()
+		w.WriteHeader(http.StatusOK)
+	}
+}
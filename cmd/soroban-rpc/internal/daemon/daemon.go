@@ -3,6 +3,7 @@ package daemon
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/pprof" //nolint:gosec
 	"os"
@@ -37,7 +38,7 @@ const (
 )
 
 type Daemon struct {
-	core                *ledgerbackend.CaptiveStellarCore
+	core                ledgerbackend.LedgerBackend
 	ingestService       *ingest.Service
 	db                  dbsession.SessionInterface
 	jsonRPCHandler      *internal.Handler
@@ -45,6 +46,8 @@ type Daemon struct {
 	logger              *supportlog.Entry
 	preflightWorkerPool *preflight.PreflightWorkerPool
 	prometheusRegistry  *prometheus.Registry
+	readiness           *readinessTracker
+	startWhenSynced     bool
 	server              *http.Server
 	adminServer         *http.Server
 	closeOnce           sync.Once
@@ -132,6 +135,19 @@ func newCaptiveCore(cfg *config.LocalConfig, logger *supportlog.Entry) (*ledgerb
 
 }
 
+// newLedgerBackend selects and constructs the ledgerbackend.LedgerBackend
+// implementation picked by cfg.LedgerBackendType.
+func newLedgerBackend(cfg *config.LocalConfig, logger *supportlog.Entry) (ledgerbackend.LedgerBackend, error) {
+	switch cfg.LedgerBackendType {
+	case config.LedgerBackendCaptiveCore:
+		return newCaptiveCore(cfg, logger)
+	case config.LedgerBackendRemoteCaptiveCore:
+		return newRemoteCaptiveCore(cfg, logger)
+	default:
+		return nil, fmt.Errorf("unsupported ledger backend type: %s", cfg.LedgerBackendType)
+	}
+}
+
 func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Daemon {
 	logger := supportlog.New()
 	logger.SetLevel(cfg.LogLevel)
@@ -140,9 +156,9 @@ func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Dae
 	}
 	prometheusRegistry := prometheus.NewRegistry()
 
-	core, err := newCaptiveCore(&cfg, logger)
+	core, err := newLedgerBackend(&cfg, logger)
 	if err != nil {
-		logger.WithError(err).Fatal("could not create captive core")
+		logger.WithError(err).Fatal("could not create ledger backend")
 	}
 
 	if len(cfg.HistoryArchiveURLs) == 0 {
@@ -165,7 +181,11 @@ func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Dae
 	dbConn := dbsession.RegisterMetrics(session, "soroban_rpc", "db", prometheusRegistry)
 
 	eventStore := events.NewMemoryStore(cfg.NetworkPassphrase, cfg.EventLedgerRetentionWindow)
-	transactionStore := transactions.NewMemoryStore(cfg.NetworkPassphrase, cfg.TransactionLedgerRetentionWindow)
+	transactionStore, err := transactions.NewStore(dbConn, cfg.NetworkPassphrase, cfg.TransactionLedgerRetentionWindow)
+	if err != nil {
+		logger.WithError(err).Fatal("could not open transaction store")
+	}
+	transactions.RegisterMetrics(prometheusRegistry)
 
 	maxRetentionWindow := cfg.EventLedgerRetentionWindow
 	if cfg.TransactionLedgerRetentionWindow > maxRetentionWindow {
@@ -175,7 +195,7 @@ func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Dae
 	}
 
 	// initialize the stores using what was on the DB
-	readTxMetaCtx, cancelReadTxMeta := context.WithTimeout(context.Background(), cfg.IngestionTimeout)
+	readTxMetaCtx, cancelReadTxMeta := context.WithTimeout(context.Background(), time.Duration(cfg.IngestionTimeout))
 	defer cancelReadTxMeta()
 	txmetas, err := db.NewLedgerReader(dbConn).GetAllLedgers(readTxMetaCtx)
 	if err != nil {
@@ -204,7 +224,7 @@ func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Dae
 		NetworkPassPhrase: cfg.NetworkPassphrase,
 		Archive:           historyArchive,
 		LedgerBackend:     core,
-		Timeout:           cfg.IngestionTimeout,
+		Timeout:           time.Duration(cfg.IngestionTimeout),
 		OnIngestionRetry:  onIngestionRetry,
 	})
 
@@ -218,15 +238,20 @@ func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Dae
 		Logger:           logger,
 		CoreClient: &stellarcore.Client{
 			URL:  cfg.StellarCoreURL,
-			HTTP: &http.Client{Timeout: cfg.CoreRequestTimeout},
+			HTTP: &http.Client{Timeout: time.Duration(cfg.CoreRequestTimeout)},
 		},
 		LedgerReader:      db.NewLedgerReader(dbConn),
 		LedgerEntryReader: db.NewLedgerEntryReader(dbConn),
 		PreflightGetter:   preflightWorkerPool,
 	})
 
-	httpHandler := supporthttp.NewAPIMux(logger)
-	httpHandler.Handle("/", jsonRPCHandler)
+	apiMux := supporthttp.NewAPIMux(logger)
+	apiMux.Handle("/", jsonRPCHandler)
+	readiness := newReadinessTracker(logger, historyArchive, db.NewLedgerReader(dbConn), time.Duration(cfg.MaxHealthyLedgerLatency))
+	slogger := newSlogLogger(logger)
+	var httpHandler http.Handler = apiMux
+	httpHandler = accessLogMiddleware(slogger, readiness.LagLedgers, httpHandler)
+	httpHandler = requestIDMiddleware(httpHandler)
 	d := &Daemon{
 		logger:              logger,
 		core:                core,
@@ -236,6 +261,8 @@ func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Dae
 		db:                  dbConn,
 		preflightWorkerPool: preflightWorkerPool,
 		prometheusRegistry:  prometheusRegistry,
+		readiness:           readiness,
+		startWhenSynced:     cfg.StartWhenSynchronized,
 		done:                make(chan struct{}),
 	}
 	d.server = &http.Server{
@@ -251,8 +278,17 @@ func MustNew(cfg config.LocalConfig, endpoint string, adminEndpoint string) *Dae
 		adminMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 		adminMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 		adminMux.Handle("/metrics", promhttp.HandlerFor(d.prometheusRegistry, promhttp.HandlerOpts{}))
+		adminMux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		adminMux.Handle("/readyz", readiness)
+		adminMux.Handle("/admin/reingest", adminAuth(cfg.AdminBearerToken, newReingestHandler(logger, ingestService)))
+		adminMux.Handle("/admin/retention", adminAuth(cfg.AdminBearerToken, newRetentionHandler(logger, eventStore, transactionStore)))
+		adminMux.Handle("/admin/ingestion", adminAuth(cfg.AdminBearerToken, newIngestionStatusHandler(ingestService)))
+		adminMux.Handle("/admin/log-level", adminAuth(cfg.AdminBearerToken, newLogLevelHandler(logger)))
 		d.adminServer = &http.Server{Addr: adminEndpoint, Handler: adminMux}
 	}
+	registerAdminMetrics(prometheusRegistry)
 	d.registerMetrics()
 	return d
 }
@@ -264,12 +300,11 @@ func (d *Daemon) Run() {
 		"addr":    d.server.Addr,
 	}).Info("starting Soroban JSON RPC server")
 
-	go func() {
-		if err := d.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-			// Error starting or closing listener:
-			d.logger.WithError(err).Fatal("soroban JSON RPC server encountered fatal error")
-		}
-	}()
+	// Shutdown gracefully when we receive an interrupt signal.
+	// First server.Shutdown closes all open listeners, then closes all idle connections.
+	// Finally, it waits a grace period (10s here) for connections to return to idle and then shut down.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 
 	if d.adminServer != nil {
 		go func() {
@@ -279,11 +314,30 @@ func (d *Daemon) Run() {
 		}()
 	}
 
-	// Shutdown gracefully when we receive an interrupt signal.
-	// First server.Shutdown closes all open listeners, then closes all idle connections.
-	// Finally, it waits a grace period (10s here) for connections to return to idle and then shut down.
-	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	if d.startWhenSynced {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-signals:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		d.logger.Info("waiting for ingestion to catch up to the history archive before accepting requests")
+		if err := d.readiness.waitUntilReady(ctx); err != nil {
+			d.logger.WithError(err).Info("aborted pre-ready wait")
+			d.Close()
+			return
+		}
+		cancel()
+	}
+
+	go func() {
+		if err := d.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			// Error starting or closing listener:
+			d.logger.WithError(err).Fatal("soroban JSON RPC server encountered fatal error")
+		}
+	}()
 
 	select {
 	case <-signals:
@@ -0,0 +1,164 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	supportlog "github.com/stellar/go/support/log"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/requestid"
+)
+
+// Access log schema (config.LogFormat=json): every JSON-RPC request
+// produces one log line with at least the following fields, in addition to
+// whatever the logrus JSON formatter adds (level, time, msg):
+//
+//	request_id           string  correlation ID, also echoed as X-Request-ID
+//	method                string  JSON-RPC method name, "" if the body didn't parse
+//	params_hash           string  first 16 hex chars of sha256(raw request body)
+//	duration_ms           int     wall-clock time spent in the handler
+//	status                int     HTTP status code written to the response
+//	client_addr           string  r.RemoteAddr
+//	backend_lag_ledgers   int     ledgers behind the history archive tip, if known
+//
+// Field names and types are part of the operator-facing contract: adding a
+// field is fine, renaming or retyping one is a breaking change.
+
+// newSlogLogger returns a log/slog.Logger backed by the existing
+// logrus-based supportlog.Entry, so new code can log through the standard
+// library's structured logging API while everything still lands in the
+// same sink (stderr, text or JSON per LogFormat) that stellar/go's own
+// loggers write to.
+func newSlogLogger(logger *supportlog.Entry) *slog.Logger {
+	return slog.New(&logrusHandler{entry: logger.Logger.WithFields(logrus.Fields{})})
+}
+
+// logrusHandler adapts slog.Handler to a logrus.Entry. It only implements
+// what soroban-rpc actually needs (level + attrs); groups are flattened
+// into dotted attribute names rather than nested, since logrus has no
+// native concept of a group.
+type logrusHandler struct {
+	entry *logrus.Entry
+	group string
+}
+
+func (h *logrusHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.entry.Logger.IsLevelEnabled(slogToLogrusLevel(level))
+}
+
+func (h *logrusHandler) Handle(_ context.Context, record slog.Record) error {
+	fields := make(logrus.Fields, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		name := attr.Key
+		if h.group != "" {
+			name = h.group + "." + name
+		}
+		fields[name] = attr.Value.Any()
+		return true
+	})
+	h.entry.WithFields(fields).Log(slogToLogrusLevel(record.Level), record.Message)
+	return nil
+}
+
+func (h *logrusHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(logrus.Fields, len(attrs))
+	for _, attr := range attrs {
+		name := attr.Key
+		if h.group != "" {
+			name = h.group + "." + name
+		}
+		fields[name] = attr.Value.Any()
+	}
+	return &logrusHandler{entry: h.entry.WithFields(fields), group: h.group}
+}
+
+func (h *logrusHandler) WithGroup(name string) slog.Handler {
+	group := name
+	if h.group != "" {
+		group = h.group + "." + name
+	}
+	return &logrusHandler{entry: h.entry, group: group}
+}
+
+func slogToLogrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level >= slog.LevelError:
+		return logrus.ErrorLevel
+	case level >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case level >= slog.LevelInfo:
+		return logrus.InfoLevel
+	default:
+		return logrus.DebugLevel
+	}
+}
+
+// accessLogMiddleware logs one structured line per JSON-RPC request with
+// the fields documented for LogFormat=json: method, params_hash,
+// duration_ms, status, client_addr, backend_lag_ledgers, plus the request
+// ID assigned by requestIDMiddleware. method and params_hash are recovered
+// by peeking at the request body; params_hash (not the raw params) is
+// logged so request logs can't leak transaction contents or secrets.
+func accessLogMiddleware(slogger *slog.Logger, lagLedgers func() uint32, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		method, paramsHash := peekJSONRPCRequest(r)
+		sw := &statusCapturingWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		slogger.LogAttrs(r.Context(), slog.LevelInfo, "handled JSON-RPC request",
+			slog.String("request_id", requestid.FromContext(r.Context())),
+			slog.String("method", method),
+			slog.String("params_hash", paramsHash),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			slog.Int("status", sw.status),
+			slog.String("client_addr", r.RemoteAddr),
+			slog.Uint64("backend_lag_ledgers", uint64(lagLedgers())),
+		)
+	})
+}
+
+// peekJSONRPCRequest reads and restores r.Body to extract the "method"
+// field and a hash of the raw body for logging, without consuming the body
+// the actual JSON-RPC handler still needs to read.
+func peekJSONRPCRequest(r *http.Request) (method string, paramsHash string) {
+	if r.Body == nil {
+		return "", ""
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", ""
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.Sum256(body)
+	paramsHash = hex.EncodeToString(sum[:8])
+
+	var envelope struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil {
+		method = envelope.Method
+	}
+	return method, paramsHash
+}
+
+type statusCapturingWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusCapturingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,159 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/stellar/go/historyarchive"
+	supportlog "github.com/stellar/go/support/log"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/db"
+)
+
+// readyPollInterval is how often the readiness tracker checks ingestion
+// progress against the history archive while StartWhenSynchronized is
+// blocking Daemon.Run from binding the public listener.
+const readyPollInterval = time.Second
+
+// readinessState is the JSON body served by /readyz.
+type readinessState struct {
+	State        string `json:"state"`
+	LatestLedger uint32 `json:"latestLedger"`
+	TargetLedger uint32 `json:"targetLedger"`
+	LagSeconds   int64  `json:"lagSeconds"`
+}
+
+const (
+	readinessStateStarting = "starting"
+	readinessStateReady    = "ready"
+)
+
+// readinessTracker reports whether ingestion has caught up to the history
+// archive's latest checkpoint (or its latest ingested ledger closed within
+// MaxHealthyLedgerLatency of now), and
+// serves that state over /readyz independently of /healthz, which only
+// reflects process liveness.
+type readinessTracker struct {
+	logger             *supportlog.Entry
+	archive            historyarchive.ArchiveInterface
+	ledgerReader       db.LedgerReader
+	maxHealthyLatency  time.Duration
+	current            atomic.Value // readinessState
+}
+
+func newReadinessTracker(logger *supportlog.Entry, archive historyarchive.ArchiveInterface, ledgerReader db.LedgerReader, maxHealthyLatency time.Duration) *readinessTracker {
+	t := &readinessTracker{
+		logger:            logger,
+		archive:           archive,
+		ledgerReader:      ledgerReader,
+		maxHealthyLatency: maxHealthyLatency,
+	}
+	t.current.Store(readinessState{State: readinessStateStarting})
+	return t
+}
+
+// waitUntilReady blocks until ingestion has caught up (per isCaughtUp) or ctx
+// is cancelled, polling at readyPollInterval.
+func (t *readinessTracker) waitUntilReady(ctx context.Context) error {
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+	for {
+		ready, err := t.poll(ctx)
+		if err != nil {
+			t.logger.WithError(err).Warn("readiness check failed, will retry")
+		} else if ready {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll refreshes the current readiness snapshot and reports whether it is
+// now ready.
+func (t *readinessTracker) poll(ctx context.Context) (bool, error) {
+	targetLedger, err := latestCheckpointLedger(ctx, t.archive)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := t.ledgerReader.NewTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Done()
+	}()
+
+	var latestLedger uint32
+	var lagSeconds int64
+	haveLatestLedger := false
+	if seq, err := tx.GetLatestLedgerSequence(); err == nil {
+		latestLedger = seq
+		haveLatestLedger = true
+		if ledger, found, err := t.ledgerReader.GetLedger(ctx, seq); err == nil && found {
+			lagSeconds = time.Now().Unix() - ledger.LedgerCloseTime()
+		}
+	}
+
+	// Until ingestion has produced at least one ledger, latestLedger and
+	// lagSeconds are both stuck at their zero values, which would otherwise
+	// make the lag fallback below report ready immediately on a cold start.
+	ready := haveLatestLedger &&
+		(latestLedger >= targetLedger || time.Duration(lagSeconds)*time.Second <= t.maxHealthyLatency)
+	state := readinessState{
+		LatestLedger: latestLedger,
+		TargetLedger: targetLedger,
+		LagSeconds:   lagSeconds,
+	}
+	if ready {
+		state.State = readinessStateReady
+	} else {
+		state.State = readinessStateStarting
+	}
+	t.current.Store(state)
+	return ready, nil
+}
+
+func latestCheckpointLedger(ctx context.Context, archive historyarchive.ArchiveInterface) (uint32, error) {
+	has, err := archive.GetRootHAS()
+	if err != nil {
+		return 0, err
+	}
+	return has.CurrentLedger, nil
+}
+
+// LagLedgers returns targetLedger-latestLedger from the most recent poll,
+// for the access log's backend_lag_ledgers field.
+func (t *readinessTracker) LagLedgers() uint32 {
+	state := t.current.Load().(readinessState)
+	if state.TargetLedger <= state.LatestLedger {
+		return 0
+	}
+	return state.TargetLedger - state.LatestLedger
+}
+
+// ServeHTTP implements the /readyz endpoint: 503 with the current snapshot
+// until ingestion has caught up, 200 afterward.
+func (t *readinessTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	state := t.current.Load().(readinessState)
+	if state.State != readinessStateReady {
+		// Refresh here too, so /readyz progresses even when nothing is
+		// running waitUntilReady (StartWhenSynchronized=false).
+		if _, err := t.poll(r.Context()); err != nil {
+			t.logger.WithError(err).Warn("readiness check failed")
+		}
+		state = t.current.Load().(readinessState)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if state.State != readinessStateReady {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(state)
+}
@@ -0,0 +1,176 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	supportlog "github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/config"
+)
+
+// remoteCaptiveCore is a ledgerbackend.LedgerBackend that delegates to an
+// out-of-process captive-core instance over HTTP, so several soroban-rpc
+// instances can share one core rather than each spawning their own. It
+// expects the remote process to expose /prepare-range, /is-prepared,
+// /latest-sequence and /ledger/{sequence} endpoints returning JSON (and raw
+// XDR for the ledger body).
+type remoteCaptiveCore struct {
+	url    string
+	client *http.Client
+	logger *supportlog.Entry
+}
+
+// newRemoteCaptiveCore creates a ledger backend that talks to an
+// out-of-process captive-core over HTTP, so that several soroban-rpc
+// instances can share one core instead of each spawning their own.
+func newRemoteCaptiveCore(cfg *config.LocalConfig, logger *supportlog.Entry) (*remoteCaptiveCore, error) {
+	if cfg.RemoteCaptiveCoreURL == "" {
+		return nil, fmt.Errorf("remote captive core URL is not configured")
+	}
+	return &remoteCaptiveCore{
+		url:    cfg.RemoteCaptiveCoreURL,
+		client: &http.Client{},
+		logger: logger.WithField("subservice", "remote-captive-core"),
+	}, nil
+}
+
+// prepareRangeRequest is the body of POST {url}/prepare-range.
+type prepareRangeRequest struct {
+	From    uint32 `json:"from"`
+	To      uint32 `json:"to"`
+	Bounded bool   `json:"bounded"`
+}
+
+// PrepareRange asks the remote captive-core process to start (or confirm
+// it has already started) processing ledgerRange.
+func (r *remoteCaptiveCore) PrepareRange(ctx context.Context, ledgerRange ledgerbackend.Range) error {
+	body, err := json.Marshal(prepareRangeRequest{
+		From:    ledgerRange.From(),
+		To:      ledgerRange.To(),
+		Bounded: ledgerRange.Bounded(),
+	})
+	if err != nil {
+		return err
+	}
+	return r.post(ctx, "/prepare-range", body, nil)
+}
+
+// isPreparedResponse is the body returned by GET {url}/is-prepared.
+type isPreparedResponse struct {
+	Prepared bool `json:"prepared"`
+}
+
+// IsPrepared reports whether the remote captive-core process has already
+// prepared ledgerRange.
+func (r *remoteCaptiveCore) IsPrepared(ctx context.Context, ledgerRange ledgerbackend.Range) (bool, error) {
+	body, err := json.Marshal(prepareRangeRequest{
+		From:    ledgerRange.From(),
+		To:      ledgerRange.To(),
+		Bounded: ledgerRange.Bounded(),
+	})
+	if err != nil {
+		return false, err
+	}
+	var resp isPreparedResponse
+	if err := r.post(ctx, "/is-prepared", body, &resp); err != nil {
+		return false, err
+	}
+	return resp.Prepared, nil
+}
+
+// latestSequenceResponse is the body returned by GET {url}/latest-sequence.
+type latestSequenceResponse struct {
+	Sequence uint32 `json:"sequence"`
+}
+
+// GetLatestLedgerSequence returns the latest ledger the remote
+// captive-core process has closed.
+func (r *remoteCaptiveCore) GetLatestLedgerSequence(ctx context.Context) (uint32, error) {
+	var resp latestSequenceResponse
+	if err := r.get(ctx, "/latest-sequence", &resp); err != nil {
+		return 0, err
+	}
+	return resp.Sequence, nil
+}
+
+// GetLedger fetches the raw XDR ledger close meta for sequence from the
+// remote captive-core process.
+func (r *remoteCaptiveCore) GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url+"/ledger/"+strconv.FormatUint(uint64(sequence), 10), nil)
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return xdr.LedgerCloseMeta{}, fmt.Errorf("remote captive core returned status %d fetching ledger %d", resp.StatusCode, sequence)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+	var lcm xdr.LedgerCloseMeta
+	if err := lcm.UnmarshalBinary(raw); err != nil {
+		return xdr.LedgerCloseMeta{}, fmt.Errorf("decoding ledger %d from remote captive core: %w", sequence, err)
+	}
+	return lcm, nil
+}
+
+// Close releases the HTTP client's idle connections. The remote
+// captive-core process itself is managed out-of-band, so there's nothing
+// else for this backend to tear down.
+func (r *remoteCaptiveCore) Close() error {
+	r.client.CloseIdleConnections()
+	return nil
+}
+
+// get issues a GET request against path and decodes a JSON response into out.
+func (r *remoteCaptiveCore) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url+path, nil)
+	if err != nil {
+		return err
+	}
+	return r.do(req, out)
+}
+
+// post issues a POST request against path with the given JSON body and
+// decodes a JSON response into out, if out is non-nil.
+func (r *remoteCaptiveCore) post(ctx context.Context, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return r.do(req, out)
+}
+
+func (r *remoteCaptiveCore) do(req *http.Request, out interface{}) error {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("remote captive core returned status %d: %s", resp.StatusCode, raw)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/requestid"
+)
+
+// newRequestID returns a short random hex string. It is not a UUID: callers
+// only need it to be unique enough to grep a single request out of the
+// logs, not globally unique.
+func newRequestID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		// crypto/rand failing means the OS entropy source is broken; any
+		// request ID we return is better than panicking the server over it.
+		return "unavailable"
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// requestIDMiddleware assigns an X-Request-ID to every request that doesn't
+// already carry one, echoes it back on the response, and stores it in the
+// request context (see package requestid) for handlers and the access-log
+// middleware to pick up. A client-supplied ID that isn't requestid.Valid
+// (empty, too long, or containing characters that could break a log line or
+// a hand-built JSON payload) is replaced rather than trusted verbatim.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestid.Header, id)
+		r = r.WithContext(requestid.WithContext(r.Context(), id))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,106 @@
+// Package events holds an in-memory, ledger-bucketed cache of Soroban
+// contract events, so the getEvents RPC can page through recent events
+// without a SQLite round trip for every call.
+package events
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/xdr"
+)
+
+// ledgerEvents is every contract event emitted by one ledger.
+type ledgerEvents struct {
+	ledgerSeq uint32
+	events    []xdr.ContractEvent
+}
+
+// MemoryStore holds ingested events in memory, retaining the ledgers from
+// latest-retentionWindow through latest. Like transactions.Store, the
+// retention window is read from the ingestion goroutine and written from
+// the admin API's goroutine, so it's an atomic rather than a plain field.
+type MemoryStore struct {
+	networkPassphrase string
+	retentionWindow   atomic.Uint32
+
+	mu      sync.Mutex
+	ledgers []ledgerEvents
+}
+
+// NewMemoryStore creates an empty event store for networkPassphrase,
+// retaining events from the latest ledger and the retentionWindow ledgers
+// before it.
+func NewMemoryStore(networkPassphrase string, retentionWindow uint32) *MemoryStore {
+	m := &MemoryStore{networkPassphrase: networkPassphrase}
+	m.retentionWindow.Store(retentionWindow)
+	return m
+}
+
+// IngestEvents extracts every contract event emitted while closing lcm and
+// appends them, trimming any ledger that has fallen outside the retention
+// window.
+func (m *MemoryStore) IngestEvents(lcm xdr.LedgerCloseMeta) error {
+	reader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(m.networkPassphrase, lcm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	var ledgerContractEvents []xdr.ContractEvent
+	for {
+		tx, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		v3, ok := tx.UnsafeMeta.GetV3()
+		if !ok || v3.SorobanMeta == nil {
+			continue
+		}
+		ledgerContractEvents = append(ledgerContractEvents, v3.SorobanMeta.Events...)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ledgers = append(m.ledgers, ledgerEvents{ledgerSeq: lcm.LedgerSequence(), events: ledgerContractEvents})
+	m.trim()
+	return nil
+}
+
+// SetRetentionWindow changes the retention window at runtime and
+// immediately trims any ledgers that now fall outside of it, for the admin
+// API's POST /admin/retention.
+func (m *MemoryStore) SetRetentionWindow(window uint32) {
+	m.retentionWindow.Store(window)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trim()
+}
+
+// trim drops every bucket older than latest-retentionWindow. Callers must
+// hold m.mu.
+func (m *MemoryStore) trim() {
+	retentionWindow := m.retentionWindow.Load()
+	if retentionWindow == 0 || len(m.ledgers) == 0 {
+		return
+	}
+	latest := m.ledgers[len(m.ledgers)-1].ledgerSeq
+	if latest < retentionWindow {
+		return
+	}
+	cutoff := latest - retentionWindow
+	i := 0
+	for ; i < len(m.ledgers); i++ {
+		if m.ledgers[i].ledgerSeq >= cutoff {
+			break
+		}
+	}
+	m.ledgers = m.ledgers[i:]
+}
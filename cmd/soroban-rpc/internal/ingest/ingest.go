@@ -0,0 +1,149 @@
+// Package ingest runs the background loop that pulls ledgers from the
+// configured ledgerbackend.LedgerBackend, writes them to the database, and
+// feeds the in-memory event store and the SQLite-backed transaction store,
+// retrying through
+// transient backend errors rather than giving up.
+package ingest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	supportlog "github.com/stellar/go/support/log"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/db"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/events"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/transactions"
+)
+
+// Config groups the dependencies the ingestion service needs.
+type Config struct {
+	Logger            *supportlog.Entry
+	DB                *db.ReadWriter
+	EventStore        *events.MemoryStore
+	TransactionStore  *transactions.Store
+	NetworkPassPhrase string
+	Archive           historyarchive.ArchiveInterface
+	LedgerBackend     ledgerbackend.LedgerBackend
+	Timeout           time.Duration
+	OnIngestionRetry  func(err error, dur time.Duration)
+}
+
+// Status reports the ingestion service's current progress, for the admin
+// API's GET /admin/ingestion.
+type Status struct {
+	Cursor     uint32
+	LagLedgers uint32
+	RetryCount uint64
+}
+
+// Service runs the background ingestion loop and serves the admin API's
+// reingest and status requests.
+type Service struct {
+	cfg Config
+
+	cursor     atomic.Uint32
+	lagLedgers atomic.Uint32
+	retryCount atomic.Uint64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewService starts the background ingestion loop and returns a Service
+// that tracks its progress.
+func NewService(cfg Config) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{cfg: cfg, cancel: cancel}
+	s.wg.Add(1)
+	go s.run(ctx)
+	return s
+}
+
+// run advances the ingestion cursor one ledger at a time, retrying through
+// cfg.OnIngestionRetry on transient errors instead of giving up.
+func (s *Service) run(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		next := s.cursor.Load() + 1
+		if err := s.applyLedger(ctx, next); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.retryCount.Add(1)
+			s.cfg.OnIngestionRetry(err, s.cfg.Timeout)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.cfg.Timeout):
+			}
+			continue
+		}
+		s.cursor.Store(next)
+		if latest, err := s.cfg.LedgerBackend.GetLatestLedgerSequence(ctx); err == nil && latest >= next {
+			s.lagLedgers.Store(latest - next)
+		}
+	}
+}
+
+// applyLedger fetches a single ledger from cfg.LedgerBackend and writes it
+// to the database, the event store and the transaction store. It does not
+// touch s.cursor, so
+// it can be reused by Reingest without disturbing the live ingestion
+// cursor's forward progress.
+func (s *Service) applyLedger(ctx context.Context, seq uint32) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	ledgerRange := ledgerbackend.BoundedRange(seq, seq)
+	if err := s.cfg.LedgerBackend.PrepareRange(ctx, ledgerRange); err != nil {
+		return err
+	}
+	lcm, err := s.cfg.LedgerBackend.GetLedger(ctx, seq)
+	if err != nil {
+		return err
+	}
+	if err := s.cfg.DB.WriteLedger(ctx, lcm); err != nil {
+		return err
+	}
+	if err := s.cfg.EventStore.IngestEvents(lcm); err != nil {
+		return err
+	}
+	return s.cfg.TransactionStore.IngestTransactions(lcm)
+}
+
+// Reingest forces [fromLedger, toLedger] to be re-fetched from the ledger
+// backend and re-applied to the database, event store and transaction store,
+// independent
+// of what the background loop has already processed. It blocks until the
+// whole range has been reingested or ctx is canceled.
+func (s *Service) Reingest(ctx context.Context, fromLedger, toLedger uint32) error {
+	for seq := fromLedger; seq <= toLedger; seq++ {
+		if err := s.applyLedger(ctx, seq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status reports the ingestion service's current cursor, how far behind the
+// ledger backend it is, and how many times the background loop has retried
+// after an error.
+func (s *Service) Status() Status {
+	return Status{
+		Cursor:     s.cursor.Load(),
+		LagLedgers: s.lagLedgers.Load(),
+		RetryCount: s.retryCount.Load(),
+	}
+}
+
+// Close stops the background ingestion loop and waits for it to exit.
+func (s *Service) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
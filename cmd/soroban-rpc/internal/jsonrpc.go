@@ -0,0 +1,58 @@
+// Package internal wires the JSON-RPC method handlers in ./internal/methods
+// into a single dispatch table served on the public endpoint.
+package internal
+
+import (
+	"net/http"
+
+	"github.com/creachadair/jrpc2/handler"
+	"github.com/creachadair/jrpc2/jhttp"
+
+	"github.com/stellar/go/clients/stellarcore"
+	supportlog "github.com/stellar/go/support/log"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/config"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/db"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/events"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/methods"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/preflight"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/transactions"
+)
+
+// HandlerParams groups the dependencies NewJSONRPCHandler wires into the
+// JSON-RPC methods it registers.
+type HandlerParams struct {
+	EventStore        *events.MemoryStore
+	TransactionStore  *transactions.Store
+	Logger            *supportlog.Entry
+	CoreClient        *stellarcore.Client
+	LedgerReader      db.LedgerReader
+	LedgerEntryReader db.LedgerEntryReader
+	PreflightGetter   *preflight.PreflightWorkerPool
+}
+
+// Handler serves every JSON-RPC method soroban-rpc exposes on its public
+// endpoint, bridged over HTTP via jhttp.
+type Handler struct {
+	bridge *jhttp.Bridge
+}
+
+// ServeHTTP implements http.Handler by delegating to the jrpc2/HTTP bridge.
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.bridge.ServeHTTP(w, r)
+}
+
+// Close releases the resources held by the underlying jrpc2 bridge.
+func (h *Handler) Close() {
+	_ = h.bridge.Close()
+}
+
+// NewJSONRPCHandler builds the JSON-RPC dispatch table for the methods this
+// service implements, binding each to the dependencies in params.
+func NewJSONRPCHandler(cfg *config.Config, params HandlerParams) Handler {
+	bridge := jhttp.NewBridge(handler.Map{
+		"getLatestLedger": methods.NewGetLatestLedgerHandler(params.Logger, params.LedgerEntryReader, params.LedgerReader, params.CoreClient),
+		"getTransactions": methods.NewGetTransactionsHandler(params.Logger, &params.TransactionStore.StoreReader, params.LedgerReader),
+	}, nil)
+	return Handler{bridge: bridge}
+}
@@ -0,0 +1,38 @@
+package methods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/creachadair/jrpc2/code"
+
+	"github.com/stellar/go/support/log"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/requestid"
+)
+
+var errLedgerNotFound = errors.New("ledger not found")
+
+// sanitizedErr logs the real cause of a handler failure together with the
+// request's correlation ID, and returns a jrpc2.Error carrying only the
+// sanitized message plus that same ID in Data, so clients can hand it back
+// to us for support without us leaking internal error text to them.
+func sanitizedErr(ctx context.Context, logger *log.Entry, cause error, message string) *jrpc2.Error {
+	id := requestid.FromContext(ctx)
+	logger.WithError(cause).WithField("request_id", id).Error(message)
+	data, err := json.Marshal(struct {
+		RequestID string `json:"requestId"`
+	}{RequestID: id})
+	if err != nil {
+		// Marshaling a plain string field cannot realistically fail; if it
+		// somehow does, the caller still gets a sanitized message back.
+		data = nil
+	}
+	return &jrpc2.Error{
+		Code:    code.InternalError,
+		Message: message,
+		Data:    data,
+	}
+}
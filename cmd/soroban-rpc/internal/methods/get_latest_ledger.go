@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/creachadair/jrpc2"
-	"github.com/creachadair/jrpc2/code"
 	"github.com/creachadair/jrpc2/handler"
 
 	"github.com/stellar/go/clients/stellarcore"
@@ -29,10 +28,7 @@ func NewGetLatestLedgerHandler(logger *log.Entry, ledgerEntryReader db.LedgerEnt
 	return handler.New(func(ctx context.Context, request GetLatestLedgerRequest) (GetLatestLedgerResponse, error) {
 		tx, err := ledgerEntryReader.NewTx(ctx)
 		if err != nil {
-			return GetLatestLedgerResponse{}, &jrpc2.Error{
-				Code:    code.InternalError,
-				Message: "could not create read transaction",
-			}
+			return GetLatestLedgerResponse{}, sanitizedErr(ctx, logger, err, "could not create read transaction")
 		}
 		defer func() {
 			_ = tx.Done()
@@ -40,26 +36,20 @@ func NewGetLatestLedgerHandler(logger *log.Entry, ledgerEntryReader db.LedgerEnt
 
 		latestSequence, err := tx.GetLatestLedgerSequence()
 		if err != nil {
-			return GetLatestLedgerResponse{}, &jrpc2.Error{
-				Code:    code.InternalError,
-				Message: "could not get latest ledger sequence",
-			}
+			return GetLatestLedgerResponse{}, sanitizedErr(ctx, logger, err, "could not get latest ledger sequence")
 		}
 
 		latestLedger, found, err := ledgerReader.GetLedger(ctx, latestSequence)
-		if (err != nil) || (!found) {
-			return GetLatestLedgerResponse{}, &jrpc2.Error{
-				Code:    code.InternalError,
-				Message: "could not get latest ledger",
+		if err != nil || !found {
+			if err == nil {
+				err = errLedgerNotFound
 			}
+			return GetLatestLedgerResponse{}, sanitizedErr(ctx, logger, err, "could not get latest ledger")
 		}
 
 		info, err := coreClient.Info(ctx)
 		if err != nil {
-			return GetLatestLedgerResponse{}, (&jrpc2.Error{
-				Code:    code.InternalError,
-				Message: err.Error(),
-			})
+			return GetLatestLedgerResponse{}, sanitizedErr(ctx, logger, err, "could not get core info")
 		}
 		response := GetLatestLedgerResponse{
 			Hash:            latestLedger.LedgerHash().HexString(),
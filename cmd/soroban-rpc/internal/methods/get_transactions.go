@@ -0,0 +1,190 @@
+package methods
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/creachadair/jrpc2/code"
+	"github.com/creachadair/jrpc2/handler"
+
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/db"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/transactions"
+)
+
+// transactionStatusSuccess/Failed are the only values the status field of
+// a getTransactions row can take.
+const (
+	transactionStatusSuccess = "SUCCESS"
+	transactionStatusFailed  = "FAILED"
+)
+
+// maxGetTransactionsLimit bounds how many transactions a single
+// getTransactions call can return, regardless of what the client asks for.
+const maxGetTransactionsLimit = 200
+
+// defaultGetTransactionsLimit is used when the client omits pagination.limit.
+const defaultGetTransactionsLimit = 50
+
+type TransactionsPaginationOptions struct {
+	Cursor string `json:"cursor,omitempty"`
+	Limit  uint   `json:"limit,omitempty"`
+}
+
+type GetTransactionsRequest struct {
+	StartLedger uint32                         `json:"startLedger"`
+	Pagination  *TransactionsPaginationOptions `json:"pagination,omitempty"`
+}
+
+type TransactionInfo struct {
+	Status           string `json:"status"`
+	ApplicationOrder int32  `json:"applicationOrder"`
+	FeeBump          bool   `json:"feeBump"`
+	EnvelopeXdr      string `json:"envelopeXdr"`
+	ResultXdr        string `json:"resultXdr"`
+	ResultMetaXdr    string `json:"resultMetaXdr"`
+	Ledger           uint32 `json:"ledger"`
+	CreatedAt        int64  `json:"createdAt"`
+}
+
+type GetTransactionsResponse struct {
+	Transactions              []TransactionInfo `json:"transactions"`
+	LatestLedger               uint32            `json:"latestLedger"`
+	LatestLedgerCloseTimestamp int64             `json:"latestLedgerCloseTimestamp"`
+	OldestLedger               uint32            `json:"oldestLedger"`
+	OldestLedgerCloseTimestamp int64             `json:"oldestLedgerCloseTimestamp"`
+	Cursor                     string            `json:"cursor"`
+}
+
+// NewGetTransactionsHandler returns a JSON RPC handler to page through
+// ingested transactions, starting at startLedger or resuming from
+// pagination.cursor.
+func NewGetTransactionsHandler(logger *log.Entry, store *transactions.StoreReader, ledgerReader db.LedgerReader) jrpc2.Handler {
+	return handler.New(func(ctx context.Context, request GetTransactionsRequest) (GetTransactionsResponse, error) {
+		limit := uint(defaultGetTransactionsLimit)
+		cursor := transactions.Cursor{Ledger: request.StartLedger}
+		if request.Pagination != nil {
+			if request.Pagination.Cursor != "" {
+				parsed, err := transactions.ParseCursor(request.Pagination.Cursor)
+				if err != nil {
+					return GetTransactionsResponse{}, &jrpc2.Error{
+						Code:    code.InvalidParams,
+						Message: "invalid pagination cursor",
+					}
+				}
+				cursor = parsed
+			}
+			if request.Pagination.Limit != 0 {
+				limit = request.Pagination.Limit
+			}
+		}
+		if limit > maxGetTransactionsLimit {
+			limit = maxGetTransactionsLimit
+		}
+
+		tx, err := ledgerReader.NewTx(ctx)
+		if err != nil {
+			return GetTransactionsResponse{}, sanitizedErr(ctx, logger, err, "could not create read transaction")
+		}
+		defer func() {
+			_ = tx.Done()
+		}()
+
+		latestLedger, err := tx.GetLatestLedgerSequence()
+		if err != nil {
+			return GetTransactionsResponse{}, sanitizedErr(ctx, logger, err, "could not get latest ledger sequence")
+		}
+		oldestLedger, latestLedgerCloseTime, oldestLedgerCloseTime, err := ledgerBounds(ctx, ledgerReader, latestLedger)
+		if err != nil {
+			return GetTransactionsResponse{}, sanitizedErr(ctx, logger, err, "could not determine ledger retention bounds")
+		}
+
+		if cursor.Ledger != 0 && cursor.Ledger > latestLedger {
+			return GetTransactionsResponse{}, &jrpc2.Error{
+				Code:    code.InvalidParams,
+				Message: "start ledger is past the latest ingested ledger",
+			}
+		}
+
+		rows, err := store.GetTransactions(ctx, cursor, int(limit))
+		if err != nil {
+			return GetTransactionsResponse{}, sanitizedErr(ctx, logger, err, "could not fetch transactions")
+		}
+
+		response := GetTransactionsResponse{
+			LatestLedger:               latestLedger,
+			LatestLedgerCloseTimestamp: latestLedgerCloseTime,
+			OldestLedger:               oldestLedger,
+			OldestLedgerCloseTimestamp: oldestLedgerCloseTime,
+			Cursor:                     cursor.String(),
+		}
+		closeTimeByLedger := make(map[uint32]int64, len(rows))
+		for _, row := range rows {
+			status, err := transactionStatus(row.ResultXDR)
+			if err != nil {
+				return GetTransactionsResponse{}, sanitizedErr(ctx, logger, err, "could not decode transaction result")
+			}
+			createdAt, ok := closeTimeByLedger[row.Ledger]
+			if !ok {
+				ledger, found, err := ledgerReader.GetLedger(ctx, row.Ledger)
+				if err != nil {
+					return GetTransactionsResponse{}, sanitizedErr(ctx, logger, err, "could not get ledger close time")
+				}
+				if found {
+					createdAt = ledger.LedgerCloseTime()
+				}
+				closeTimeByLedger[row.Ledger] = createdAt
+			}
+			response.Transactions = append(response.Transactions, TransactionInfo{
+				Status:           status,
+				ApplicationOrder: row.ApplicationOrder,
+				FeeBump:          row.FeeBump,
+				EnvelopeXdr:      base64.StdEncoding.EncodeToString(row.EnvelopeXDR),
+				ResultXdr:        base64.StdEncoding.EncodeToString(row.ResultXDR),
+				ResultMetaXdr:    base64.StdEncoding.EncodeToString(row.ResultMetaXDR),
+				Ledger:           row.Ledger,
+				CreatedAt:        createdAt,
+			})
+			response.Cursor = transactions.Cursor{Ledger: row.Ledger, TxIdx: row.ApplicationOrder}.String()
+		}
+		return response, nil
+	})
+}
+
+// transactionStatus decodes a raw xdr.TransactionResult to determine whether
+// the transaction it belongs to succeeded.
+func transactionStatus(resultXDR []byte) (string, error) {
+	var result xdr.TransactionResult
+	if err := xdr.SafeUnmarshal(resultXDR, &result); err != nil {
+		return "", err
+	}
+	if result.Successful() {
+		return transactionStatusSuccess, nil
+	}
+	return transactionStatusFailed, nil
+}
+
+// ledgerBounds returns the oldest ledger still retained, along with the
+// close timestamps of the oldest and latest ledgers, so clients can tell
+// whether their requested startLedger/cursor has already rolled off.
+func ledgerBounds(ctx context.Context, ledgerReader db.LedgerReader, latestLedger uint32) (oldestLedger uint32, latestCloseTime int64, oldestCloseTime int64, err error) {
+	latest, _, err := ledgerReader.GetLedger(ctx, latestLedger)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	oldestLedger, found, err := ledgerReader.GetOldestLedgerSequence(ctx)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if !found {
+		oldestLedger = latestLedger
+	}
+	oldest, _, err := ledgerReader.GetLedger(ctx, oldestLedger)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return oldestLedger, latest.LedgerCloseTime(), oldest.LedgerCloseTime(), nil
+}
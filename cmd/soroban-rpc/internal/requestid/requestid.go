@@ -0,0 +1,43 @@
+// Package requestid carries the per-request correlation ID assigned by the
+// daemon's HTTP middleware through to the JSON-RPC method handlers, so both
+// the access log and any error returned to the client can be tied back to
+// the same request without threading an extra parameter through every
+// handler signature.
+package requestid
+
+import (
+	"context"
+	"regexp"
+)
+
+// Header is the HTTP header clients may set (and will always receive back)
+// to correlate a JSON-RPC call across the access log, the handler's own log
+// lines, and any error Data returned to them.
+const Header = "X-Request-ID"
+
+// maxLen bounds a client-supplied request ID; newRequestID's own IDs are far
+// shorter than this, it's purely a guard against abuse.
+const maxLen = 128
+
+var validPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Valid reports whether id is safe to accept from a client: non-empty,
+// bounded in length, and restricted to characters that can't break out of a
+// log line or a hand-built JSON string.
+func Valid(id string) bool {
+	return id != "" && len(id) <= maxLen && validPattern.MatchString(id)
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying id.
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored by WithContext, or "" if ctx
+// doesn't carry one (e.g. in tests that call a handler directly).
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,19 @@
+package transactions
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// transactionsIngestedCounter tracks the number of transactions written to
+// the SQLite-backed store, for operators to graph ingestion throughput
+// alongside the existing ledger/event counters.
+var transactionsIngestedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "soroban_rpc",
+	Subsystem: "transactions",
+	Name:      "ingested_total",
+	Help:      "Total number of transactions ingested into the transaction store",
+})
+
+// RegisterMetrics registers the transaction store's Prometheus collectors
+// with the given registry. Daemon calls this once during startup.
+func RegisterMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(transactionsIngestedCounter)
+}
@@ -0,0 +1,38 @@
+package transactions
+
+const sqlCreateTransactionsTable = `
+CREATE TABLE IF NOT EXISTS transactions (
+	hash              TEXT NOT NULL PRIMARY KEY,
+	ledger_seq        INTEGER NOT NULL,
+	application_order INTEGER NOT NULL,
+	is_fee_bump       BOOLEAN NOT NULL,
+	envelope_xdr      BLOB NOT NULL,
+	result_xdr        BLOB NOT NULL,
+	meta_xdr          BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS transactions_by_ledger_seq ON transactions(ledger_seq, application_order);
+`
+
+const sqlInsertTransaction = `
+INSERT INTO transactions (hash, ledger_seq, application_order, is_fee_bump, envelope_xdr, result_xdr, meta_xdr)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(hash) DO NOTHING
+`
+
+const sqlDeleteTransactionsBelow = `DELETE FROM transactions WHERE ledger_seq < ?`
+
+const sqlGetMaxLedgerSeq = `SELECT COALESCE(MAX(ledger_seq), 0) FROM transactions`
+
+const sqlGetTransaction = `
+SELECT ledger_seq, application_order, is_fee_bump, envelope_xdr, result_xdr, meta_xdr
+FROM transactions
+WHERE hash = ?
+`
+
+const sqlGetTransactionsAfterCursor = `
+SELECT ledger_seq, application_order, is_fee_bump, envelope_xdr, result_xdr, meta_xdr
+FROM transactions
+WHERE ledger_seq > ? OR (ledger_seq = ? AND application_order > ?)
+ORDER BY ledger_seq ASC, application_order ASC
+LIMIT ?
+`
@@ -0,0 +1,281 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/stellar/go/ingest"
+	dbsession "github.com/stellar/go/support/db"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+// Transaction is the row shape returned to callers of the store, mirroring
+// the fields the getTransaction(s) RPC methods expose to clients.
+type Transaction struct {
+	ApplicationOrder int32
+	FeeBump          bool
+	Ledger           uint32
+	Hash             xdr.Hash
+	EnvelopeXDR      []byte
+	ResultXDR        []byte
+	ResultMetaXDR    []byte
+}
+
+// Cursor identifies a transaction's position within the ledger stream. It is
+// encoded as a toid so it can round-trip through an opaque string while
+// remaining numerically comparable.
+type Cursor struct {
+	// Ledger is the sequence of the ledger containing the transaction.
+	Ledger uint32
+	// TxIdx is the application order of the transaction within the ledger.
+	TxIdx int32
+}
+
+func (c Cursor) toid() *toid.ID {
+	return &toid.ID{LedgerSequence: int32(c.Ledger), TransactionOrder: c.TxIdx}
+}
+
+// String encodes the cursor as the decimal string representation of its toid.
+func (c Cursor) String() string {
+	return c.toid().String()
+}
+
+// ParseCursor decodes a cursor previously produced by Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	id, err := toid.Parse(s)
+	if err != nil {
+		return Cursor{}, err
+	}
+	return Cursor{Ledger: uint32(id.LedgerSequence), TxIdx: id.TransactionOrder}, nil
+}
+
+// lruCache shortcuts GetTransaction lookups for recently ingested hashes,
+// avoiding a SQLite round trip for the common case of a client polling a
+// transaction it just submitted.
+type lruCache struct {
+	sync.Mutex
+	capacity int
+	order    []xdr.Hash
+	entries  map[xdr.Hash]Transaction
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		entries:  make(map[xdr.Hash]Transaction, capacity),
+	}
+}
+
+func (c *lruCache) get(hash xdr.Hash) (Transaction, bool) {
+	c.Lock()
+	defer c.Unlock()
+	tx, ok := c.entries[hash]
+	if ok {
+		// Move hash to the most-recently-used end so add evicts the least
+		// recently used entry rather than the oldest insertion.
+		for i, h := range c.order {
+			if h == hash {
+				c.order = append(append(c.order[:i:i], c.order[i+1:]...), hash)
+				break
+			}
+		}
+	}
+	return tx, ok
+}
+
+func (c *lruCache) add(hash xdr.Hash, tx Transaction) {
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.entries[hash]; !ok {
+		c.order = append(c.order, hash)
+		for len(c.order) > c.capacity {
+			evict := c.order[0]
+			c.order = c.order[1:]
+			delete(c.entries, evict)
+		}
+	}
+	c.entries[hash] = tx
+}
+
+// defaultLRUCapacity bounds the in-process hash cache. Its job is to absorb
+// the "submit then immediately poll" pattern, not to act as a general-purpose
+// transaction cache.
+const defaultLRUCapacity = 1000
+
+// StoreReader exposes read-only access to ingested transactions, backed by
+// SQLite rather than an in-memory ring buffer so it can serve a retention
+// window far larger than comfortably fits in RAM.
+type StoreReader struct {
+	db                dbsession.SessionInterface
+	networkPassphrase string
+	cache             *lruCache
+}
+
+// Store additionally supports ingesting and trimming transactions. It is the
+// type wired into Daemon and the ingestion service.
+type Store struct {
+	StoreReader
+	// retentionWindow is read from the ingestion goroutine (TrimTransactions)
+	// and written from the admin API's goroutine (SetRetentionWindow), so it
+	// has to be accessed atomically rather than as a plain field.
+	retentionWindow atomic.Uint32
+}
+
+// NewStore creates (if needed) the transactions table in the supplied
+// database and returns a Store ready to be used by the ingestion service and
+// the getTransaction(s) RPC handlers.
+func NewStore(dbConn dbsession.SessionInterface, networkPassphrase string, retentionWindow uint32) (*Store, error) {
+	if _, err := dbConn.ExecRaw(context.Background(), sqlCreateTransactionsTable); err != nil {
+		return nil, err
+	}
+	store := &Store{
+		StoreReader: StoreReader{
+			db:                dbConn,
+			networkPassphrase: networkPassphrase,
+			cache:             newLRUCache(defaultLRUCapacity),
+		},
+	}
+	store.retentionWindow.Store(retentionWindow)
+	return store, nil
+}
+
+// IngestTransactions reads every transaction out of the given ledger close
+// meta and writes it to the transactions table, refreshing the LRU cache for
+// each one. It mirrors the signature of the event store's IngestEvents so
+// Daemon can ingest both stores identically.
+func (s *Store) IngestTransactions(lcm xdr.LedgerCloseMeta) error {
+	ctx := context.Background()
+	reader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(s.networkPassphrase, lcm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	for {
+		tx, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		envelopeXDR, err := tx.Envelope.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		resultXDR, err := tx.Result.Result.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		metaXDR, err := tx.UnsafeMeta.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if err := s.insertTransaction(ctx, tx.Result.TransactionHash, Transaction{
+			Ledger:           lcm.LedgerSequence(),
+			ApplicationOrder: int32(tx.Index),
+			FeeBump:          tx.Envelope.IsFeeBump(),
+			EnvelopeXDR:      envelopeXDR,
+			ResultXDR:        resultXDR,
+			ResultMetaXDR:    metaXDR,
+		}); err != nil {
+			return err
+		}
+	}
+	return s.TrimTransactions(ctx, lcm.LedgerSequence())
+}
+
+// insertTransaction persists a single ingested transaction row and refreshes
+// the in-process LRU cache for it. The insert runs on the store's own
+// session, outside the transaction that writes the ledger's meta, so it is
+// not rolled back if the rest of that ledger's ingestion fails.
+func (s *Store) insertTransaction(ctx context.Context, hash xdr.Hash, tx Transaction) error {
+	_, err := s.db.ExecRaw(ctx, sqlInsertTransaction,
+		hash.HexString(), tx.Ledger, tx.ApplicationOrder, tx.FeeBump,
+		tx.EnvelopeXDR, tx.ResultXDR, tx.ResultMetaXDR,
+	)
+	if err != nil {
+		return err
+	}
+	transactionsIngestedCounter.Inc()
+	s.cache.add(hash, tx)
+	return nil
+}
+
+// TrimTransactions deletes every row for a ledger older than
+// latestLedger-retentionWindow. The delete runs on the store's own session,
+// not in the DB transaction that trims ledger meta, so the two trims are not
+// atomic with each other.
+func (s *Store) TrimTransactions(ctx context.Context, latestLedger uint32) error {
+	retentionWindow := s.retentionWindow.Load()
+	if retentionWindow == 0 || latestLedger < retentionWindow {
+		return nil
+	}
+	cutoff := latestLedger - retentionWindow
+	_, err := s.db.ExecRaw(ctx, sqlDeleteTransactionsBelow, cutoff)
+	return err
+}
+
+// SetRetentionWindow changes the retention window at runtime and immediately
+// trims any rows that fall outside of it, for the admin API's
+// POST /admin/retention.
+func (s *Store) SetRetentionWindow(ctx context.Context, window uint32) error {
+	s.retentionWindow.Store(window)
+	var latestLedger uint32
+	err := s.db.QueryRow(ctx, sqlGetMaxLedgerSeq).Scan(&latestLedger)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.TrimTransactions(ctx, latestLedger)
+}
+
+// GetTransaction looks up a transaction by hash, consulting the in-process
+// LRU before falling back to SQLite.
+func (r *StoreReader) GetTransaction(ctx context.Context, hash xdr.Hash) (Transaction, bool, error) {
+	if tx, ok := r.cache.get(hash); ok {
+		return tx, true, nil
+	}
+	tx := Transaction{Hash: hash}
+	err := r.db.QueryRow(ctx, sqlGetTransaction, hash.HexString()).Scan(
+		&tx.Ledger, &tx.ApplicationOrder, &tx.FeeBump, &tx.EnvelopeXDR, &tx.ResultXDR, &tx.ResultMetaXDR,
+	)
+	if err == sql.ErrNoRows {
+		return Transaction{}, false, nil
+	}
+	if err != nil {
+		return Transaction{}, false, err
+	}
+	return tx, true, nil
+}
+
+// GetTransactions returns up to limit transactions starting strictly after
+// cursor, ordered by (ledger, applicationOrder). Passing the zero Cursor
+// starts from the oldest transaction still within the retention window.
+func (r *StoreReader) GetTransactions(ctx context.Context, cursor Cursor, limit int) ([]Transaction, error) {
+	rows, err := r.db.Query(ctx, sqlGetTransactionsAfterCursor, cursor.Ledger, cursor.Ledger, cursor.TxIdx, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Transaction
+	for rows.Next() {
+		var tx Transaction
+		if err := rows.Scan(&tx.Ledger, &tx.ApplicationOrder, &tx.FeeBump, &tx.EnvelopeXDR, &tx.ResultXDR, &tx.ResultMetaXDR); err != nil {
+			return nil, err
+		}
+		result = append(result, tx)
+	}
+	return result, rows.Err()
+}
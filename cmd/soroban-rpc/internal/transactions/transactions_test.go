@@ -0,0 +1,231 @@
+package transactions
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	dbsession "github.com/stellar/go/support/db"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, cursor := range []Cursor{
+		{Ledger: 1, TxIdx: 0},
+		{Ledger: 1000000, TxIdx: 42},
+		{Ledger: 0, TxIdx: 0},
+	} {
+		parsed, err := ParseCursor(cursor.String())
+		if err != nil {
+			t.Fatalf("ParseCursor(%q) returned error: %v", cursor.String(), err)
+		}
+		if parsed != cursor {
+			t.Fatalf("round trip mismatch: got %+v, want %+v", parsed, cursor)
+		}
+	}
+}
+
+func TestParseCursorInvalid(t *testing.T) {
+	if _, err := ParseCursor("not-a-cursor"); err == nil {
+		t.Fatal("expected an error parsing an invalid cursor")
+	}
+}
+
+func TestLRUCacheEvictsOldest(t *testing.T) {
+	cache := newLRUCache(2)
+	var h1, h2, h3 [32]byte
+	h1[0], h2[0], h3[0] = 1, 2, 3
+
+	cache.add(h1, Transaction{Ledger: 1})
+	cache.add(h2, Transaction{Ledger: 2})
+	cache.add(h3, Transaction{Ledger: 3})
+
+	if _, ok := cache.get(h1); ok {
+		t.Fatal("expected oldest entry to have been evicted")
+	}
+	if _, ok := cache.get(h2); !ok {
+		t.Fatal("expected h2 to still be cached")
+	}
+	if _, ok := cache.get(h3); !ok {
+		t.Fatal("expected h3 to still be cached")
+	}
+}
+
+// openTestDB opens a fresh on-disk SQLite database at path, so tests that
+// want two independent sessions against the same data (e.g. to bypass one
+// Store's in-process LRU cache) can reopen it.
+func openTestDB(t *testing.T, path string) dbsession.SessionInterface {
+	t.Helper()
+	session, err := dbsession.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = session.Close()
+	})
+	return session
+}
+
+func newTestStore(t *testing.T, path string, retentionWindow uint32) *Store {
+	t.Helper()
+	store, err := NewStore(openTestDB(t, path), "test passphrase", retentionWindow)
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	return store
+}
+
+func TestGetTransactionsEmptyDB(t *testing.T) {
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.sqlite"), 0)
+
+	rows, err := store.GetTransactions(context.Background(), Cursor{}, 10)
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows from an empty database, got %d", len(rows))
+	}
+}
+
+func TestGetTransactionEmptyDB(t *testing.T) {
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.sqlite"), 0)
+
+	var hash [32]byte
+	hash[0] = 1
+	_, found, err := store.GetTransaction(context.Background(), hash)
+	if err != nil {
+		t.Fatalf("GetTransaction returned error: %v", err)
+	}
+	if found {
+		t.Fatal("expected no transaction to be found in an empty database")
+	}
+}
+
+func TestGetTransactionsCursorPastLatest(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.sqlite"), 0)
+
+	for i := int32(0); i < 3; i++ {
+		var hash [32]byte
+		hash[0] = byte(i + 1)
+		if err := store.insertTransaction(ctx, hash, Transaction{Ledger: 10, ApplicationOrder: i + 1}); err != nil {
+			t.Fatalf("insertTransaction returned error: %v", err)
+		}
+	}
+
+	rows, err := store.GetTransactions(ctx, Cursor{Ledger: 1000}, 10)
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Fatalf("expected no rows past the latest ingested ledger, got %d", len(rows))
+	}
+}
+
+func TestGetTransactionsAcrossLedgerGaps(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.sqlite"), 0)
+
+	for _, ledger := range []uint32{1, 2, 5, 9} {
+		var hash [32]byte
+		hash[0] = byte(ledger)
+		if err := store.insertTransaction(ctx, hash, Transaction{Ledger: ledger, ApplicationOrder: 1}); err != nil {
+			t.Fatalf("insertTransaction(%d) returned error: %v", ledger, err)
+		}
+	}
+
+	rows, err := store.GetTransactions(ctx, Cursor{}, 10)
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	var gotLedgers []uint32
+	for _, row := range rows {
+		gotLedgers = append(gotLedgers, row.Ledger)
+	}
+	wantLedgers := []uint32{1, 2, 5, 9}
+	if len(gotLedgers) != len(wantLedgers) {
+		t.Fatalf("got %v, want %v", gotLedgers, wantLedgers)
+	}
+	for i := range wantLedgers {
+		if gotLedgers[i] != wantLedgers[i] {
+			t.Fatalf("got %v, want %v", gotLedgers, wantLedgers)
+		}
+	}
+}
+
+func TestTrimTransactionsRespectsRetentionWindow(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.sqlite"), 2)
+
+	for ledger := uint32(1); ledger <= 5; ledger++ {
+		var hash [32]byte
+		hash[0] = byte(ledger)
+		if err := store.insertTransaction(ctx, hash, Transaction{Ledger: ledger, ApplicationOrder: 1}); err != nil {
+			t.Fatalf("insertTransaction(%d) returned error: %v", ledger, err)
+		}
+	}
+
+	if err := store.TrimTransactions(ctx, 5); err != nil {
+		t.Fatalf("TrimTransactions returned error: %v", err)
+	}
+
+	rows, err := store.GetTransactions(ctx, Cursor{}, 10)
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	if len(rows) != 3 || rows[0].Ledger != 3 || rows[1].Ledger != 4 || rows[2].Ledger != 5 {
+		t.Fatalf("expected only ledgers 3 through 5 to survive trimming, got %+v", rows)
+	}
+}
+
+func TestSetRetentionWindowTrimsImmediately(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.sqlite"), 0)
+
+	for ledger := uint32(1); ledger <= 5; ledger++ {
+		var hash [32]byte
+		hash[0] = byte(ledger)
+		if err := store.insertTransaction(ctx, hash, Transaction{Ledger: ledger, ApplicationOrder: 1}); err != nil {
+			t.Fatalf("insertTransaction(%d) returned error: %v", ledger, err)
+		}
+	}
+
+	if err := store.SetRetentionWindow(ctx, 2); err != nil {
+		t.Fatalf("SetRetentionWindow returned error: %v", err)
+	}
+
+	rows, err := store.GetTransactions(ctx, Cursor{}, 10)
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	if len(rows) != 3 || rows[0].Ledger != 3 || rows[1].Ledger != 4 || rows[2].Ledger != 5 {
+		t.Fatalf("expected SetRetentionWindow to trim immediately, got %+v", rows)
+	}
+}
+
+func TestGetTransactionFallsBackToDatabase(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.sqlite")
+
+	writer := newTestStore(t, path, 0)
+	var hash [32]byte
+	hash[0] = 7
+	want := Transaction{Ledger: 3, ApplicationOrder: 1, EnvelopeXDR: []byte("envelope"), ResultXDR: []byte("result"), ResultMetaXDR: []byte("meta")}
+	if err := writer.insertTransaction(ctx, hash, want); err != nil {
+		t.Fatalf("insertTransaction returned error: %v", err)
+	}
+
+	// A second Store against the same file has an empty LRU cache, so this
+	// exercises the SQLite fallback path rather than the cache hit.
+	reader := newTestStore(t, path, 0)
+	got, found, err := reader.GetTransaction(ctx, hash)
+	if err != nil {
+		t.Fatalf("GetTransaction returned error: %v", err)
+	}
+	if !found {
+		t.Fatal("expected the previously-inserted transaction to be found")
+	}
+	if got.Ledger != want.Ledger || got.ApplicationOrder != want.ApplicationOrder {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/config"
+	"github.com/stellar/soroban-tools/cmd/soroban-rpc/internal/daemon"
+)
+
+func main() {
+	cfg := &config.Config{}
+	cfg.SetDefaults()
+
+	// The config file layer has to be applied before flags are registered:
+	// each flag's default Value is read off cfg below, and urfave/cli only
+	// overrides a Destination when the flag is actually set on the CLI or
+	// via its env var, so whatever is already in cfg at that point acts as
+	// the file/defaults layer in the CLI > env > file > defaults chain.
+	if path := configPathFromArgs(os.Args); path != "" {
+		fileCfg, err := config.Read(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "reading config file %s: %s\n", path, err)
+			os.Exit(1)
+		}
+		cfg.ConfigPath = path
+		*cfg = cfg.Merge(*fileCfg)
+	}
+
+	app := &cli.App{
+		Name:  "soroban-rpc",
+		Usage: "run a Soroban JSON-RPC server",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:        "config-path",
+				Usage:       "Path to a TOML config file to load before applying env vars and CLI flags",
+				Destination: &cfg.ConfigPath,
+				Value:       cfg.ConfigPath,
+			},
+		}, mustFlags(cfg)...),
+		// Before runs once flags are parsed but before Action/Commands
+		// dispatch, so it applies to gen-config too: cli can bind a
+		// Destination straight into cfg for string/bool/uint fields, but
+		// uint32 fields and custom types (LogFormat, LedgerBackendType,
+		// durations, log level) need this extra step to copy the parsed
+		// value back.
+		Before: func(c *cli.Context) error {
+			return cfg.Options().SetValues(c)
+		},
+		Action: func(c *cli.Context) error {
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			daemon.MustNew(config.LocalConfig(*cfg), cfg.Endpoint, cfg.AdminEndpoint).Run()
+			return nil
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "gen-config",
+				Usage: "print a fully-commented TOML template of the effective configuration and exit",
+				Action: func(c *cli.Context) error {
+					return cfg.Options().WriteTemplate(os.Stdout)
+				},
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// configPathFromArgs does a minimal pre-scan for --config-path / -config-path
+// so the file layer can be loaded before the full flag set (whose defaults
+// depend on it) is constructed. It intentionally understands only this one
+// flag; everything else is left to urfave/cli's normal parsing.
+func configPathFromArgs(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "--config-path" || arg == "-config-path":
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+		case strings.HasPrefix(arg, "--config-path="):
+			return strings.TrimPrefix(arg, "--config-path=")
+		}
+	}
+	if path := os.Getenv("CONFIG_PATH"); path != "" {
+		return path
+	}
+	return ""
+}
+
+func mustFlags(cfg *config.Config) []cli.Flag {
+	flags, err := cfg.Options().Flags()
+	if err != nil {
+		panic(err)
+	}
+	return flags
+}